@@ -8,11 +8,19 @@ SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
 BUILD_DIR="$SCRIPT_DIR/build"
 BINARY_NAME="mediaheist"
 
-# 清理建置目錄
-rm -rf "$BUILD_DIR"
-mkdir -p "$BUILD_DIR"
+# --test：只執行測試，不建置也不清理建置目錄
+TEST_ONLY=false
+if [[ "${1:-}" == "--test" ]]; then
+    TEST_ONLY=true
+fi
+
+if [[ "$TEST_ONLY" == false ]]; then
+    # 清理建置目錄
+    rm -rf "$BUILD_DIR"
+    mkdir -p "$BUILD_DIR"
 
-echo "正在建置 MediaHeist Go 二進制檔案..."
+    echo "正在建置 MediaHeist Go 二進制檔案..."
+fi
 
 # 檢查 Go 是否已安裝
 if ! command -v go >/dev/null 2>&1; then
@@ -38,6 +46,17 @@ fi
 cd "$SCRIPT_DIR/cmd/mediaheist"
 echo "now path: $(pwd)"
 
+# 執行測試（測試會用到 assets 目錄中的嵌入檔案，需在更新 assets 後執行）
+echo "執行測試..."
+go vet ./...
+go test ./...
+echo "✓ 測試通過"
+
+if [[ "$TEST_ONLY" == true ]]; then
+    rm -rf "$SCRIPT_DIR/cmd/mediaheist/assets"
+    exit 0
+fi
+
 # 建置不同平台的二進制檔案
 platforms=(
     "darwin/arm64"
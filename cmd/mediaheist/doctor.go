@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// requiredEnvKeys 必需的環境變數（需與 Makefile 的 REQUIRED_VARS 保持一致）
+var requiredEnvKeys = []string{"GEMINI_API_KEY", "GEMINI_MODEL_ID", "WHISPER_BIN", "WHISPER_MODEL"}
+
+// envKeyPattern 合法的環境變數名稱
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// envIssue 描述 .env 檔案中的一個問題
+type envIssue struct {
+	line int // 行號（0 表示不屬於特定行）
+	msg  string
+}
+
+// validateEnvFile 解析 .env 檔案，檢查格式錯誤與缺少的必需變數
+func validateEnvFile(path string) ([]envIssue, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var issues []envIssue
+	values := make(map[string]string)
+	definedAt := make(map[string]int)
+
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		// 跳過空行與註解
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		rawKey, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			issues = append(issues, envIssue{lineNo, msg("envNoEquals")})
+			continue
+		}
+
+		key := strings.TrimSpace(rawKey)
+		if !envKeyPattern.MatchString(key) {
+			issues = append(issues, envIssue{lineNo, msg("envInvalidKey", key)})
+			continue
+		}
+		if rawKey != key || strings.HasPrefix(rawValue, " ") || strings.HasPrefix(rawValue, "\t") {
+			issues = append(issues, envIssue{lineNo, msg("envSpacing", key, key)})
+		}
+
+		// 與 make 相同，# 之後視為註解
+		value, _, _ := strings.Cut(rawValue, "#")
+		value = strings.TrimSpace(value)
+
+		if prev, ok := definedAt[key]; ok {
+			issues = append(issues, envIssue{lineNo, msg("envDuplicate", key, prev)})
+		}
+		definedAt[key] = lineNo
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// 檢查必需變數（Makefile 以 -include 載入 .env，.env 中的指定會覆蓋環境變數，
+	// 因此只有 .env 未定義時才允許由環境變數提供）
+	for _, key := range requiredEnvKeys {
+		value, ok := values[key]
+		switch {
+		case ok && value == "":
+			issues = append(issues, envIssue{definedAt[key], msg("envEmptyRequired", key)})
+		case !ok && os.Getenv(key) == "":
+			issues = append(issues, envIssue{0, msg("envMissingRequired", key)})
+		}
+	}
+
+	return issues, nil
+}
+
+// checkEnvFile 檢查工作目錄中的 .env 並顯示問題，回傳是否通過檢查
+func checkEnvFile(dir string) bool {
+	envPath := filepath.Join(dir, ".env")
+	if _, err := os.Stat(envPath); os.IsNotExist(err) {
+		// 缺少檔案的警告由 checkConfigFiles 顯示
+		return false
+	}
+
+	issues, err := validateEnvFile(envPath)
+	if err != nil {
+		printWarn("envReadFailed", err)
+		return false
+	}
+
+	for _, issue := range issues {
+		if issue.line > 0 {
+			printWarn("envIssueAtLine", issue.line, issue.msg)
+		} else {
+			printWarn("envIssue", issue.msg)
+		}
+	}
+
+	if len(issues) == 0 {
+		printInfo("envOK")
+	}
+	return len(issues) == 0
+}
+
+// checkPromptFile 檢查工作目錄中的 prompt.txt 並顯示大小與問題，回傳是否通過檢查
+func checkPromptFile(dir string) bool {
+	content, err := os.ReadFile(filepath.Join(dir, "prompt.txt"))
+	if os.IsNotExist(err) {
+		// prompt.txt 不在嵌入檔案中，缺少時 pre_srt_summary.sh 必定失敗
+		printWarn("promptMissing")
+		printWarn("promptMissingHint")
+		return false
+	}
+	if err != nil {
+		printWarn("promptReadFailed", err)
+		return false
+	}
+
+	if strings.TrimSpace(string(content)) == "" {
+		printWarn("promptEmpty")
+		return false
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if strings.HasSuffix(string(content), "\n") {
+		lines = lines[:len(lines)-1]
+	}
+	for i, line := range lines {
+		if !utf8.ValidString(line) {
+			printWarn("promptInvalidUTF8", i+1)
+			return false
+		}
+	}
+
+	printInfo("promptInfo", len(content), len(lines))
+	return true
+}
+
+// runDoctor 只執行配置檢查，不解壓縮也不執行 make，有任何檢查未通過時回傳錯誤
+func runDoctor(dir string) error {
+	printInfo("doctorHeader", dir)
+	checkConfigFiles(dir)
+
+	envOK := checkEnvFile(dir)
+	promptOK := checkPromptFile(dir)
+	if !envOK || !promptOK {
+		return errors.New(msg("doctorFailed"))
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// requiredEnvBlock 補齊所有必需變數，讓測試只關注其餘行的問題
+const requiredEnvBlock = `
+GEMINI_API_KEY=key
+GEMINI_MODEL_ID=model
+WHISPER_BIN=whisper
+WHISPER_MODEL=base
+`
+
+// clearRequiredEnv 清除必需變數的環境變數，避免執行測試的環境影響結果
+func clearRequiredEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range requiredEnvKeys {
+		t.Setenv(key, "")
+	}
+}
+
+// writeTempFile 在暫存目錄寫入檔案並回傳路徑
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestValidateEnvFileExample(t *testing.T) {
+	clearRequiredEnv(t)
+
+	issues, err := validateEnvFile(filepath.Join("..", "..", ".env.example"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 0 {
+		t.Errorf(".env.example issues = %v, want none", issues)
+	}
+}
+
+func TestValidateEnvFile(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []envIssue
+	}{
+		{"comments and blank lines", "# comment\n\n   # indented\n", nil},
+		{"export prefix", "export MAX_JOBS=4\n", nil},
+		{"trailing comment", "MAX_SAFE_SIZE_KB=150  # Max size\n", nil},
+		{"missing equals", "MAX_JOBS\n", []envIssue{{1, msg("envNoEquals")}}},
+		{"invalid key", "1JOBS=4\n", []envIssue{{1, msg("envInvalidKey", "1JOBS")}}},
+		{"spaces around equals", "MAX_JOBS = 4\n", []envIssue{{1, msg("envSpacing", "MAX_JOBS", "MAX_JOBS")}}},
+		{"space after equals", "MAX_JOBS= 4\n", []envIssue{{1, msg("envSpacing", "MAX_JOBS", "MAX_JOBS")}}},
+		{"duplicate key", "MAX_JOBS=4\n# again\nMAX_JOBS=8\n", []envIssue{{3, msg("envDuplicate", "MAX_JOBS", 1)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearRequiredEnv(t)
+
+			issues, err := validateEnvFile(writeTempFile(t, ".env", tt.body+requiredEnvBlock))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(issues, tt.want) {
+				t.Errorf("issues = %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateEnvFileRequiredKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		env  map[string]string
+		want []envIssue
+	}{
+		{
+			name: "all missing",
+			body: "MAX_JOBS=4\n",
+			want: []envIssue{
+				{0, msg("envMissingRequired", "GEMINI_API_KEY")},
+				{0, msg("envMissingRequired", "GEMINI_MODEL_ID")},
+				{0, msg("envMissingRequired", "WHISPER_BIN")},
+				{0, msg("envMissingRequired", "WHISPER_MODEL")},
+			},
+		},
+		{
+			name: "empty value",
+			body: "GEMINI_API_KEY=\nGEMINI_MODEL_ID=model\nWHISPER_BIN=whisper\nWHISPER_MODEL=base\n",
+			want: []envIssue{{1, msg("envEmptyRequired", "GEMINI_API_KEY")}},
+		},
+		{
+			name: "comment-only value",
+			body: "GEMINI_API_KEY= # fill in\nGEMINI_MODEL_ID=model\nWHISPER_BIN=whisper\nWHISPER_MODEL=base\n",
+			want: []envIssue{
+				{1, msg("envSpacing", "GEMINI_API_KEY", "GEMINI_API_KEY")},
+				{1, msg("envEmptyRequired", "GEMINI_API_KEY")},
+			},
+		},
+		{
+			name: "provided by environment",
+			body: "GEMINI_API_KEY=key\nGEMINI_MODEL_ID=model\n",
+			env:  map[string]string{"WHISPER_BIN": "whisper", "WHISPER_MODEL": "base"},
+			want: nil,
+		},
+		{
+			name: "empty value overrides environment",
+			body: "GEMINI_API_KEY=\nGEMINI_MODEL_ID=model\nWHISPER_BIN=whisper\nWHISPER_MODEL=base\n",
+			env:  map[string]string{"GEMINI_API_KEY": "fromenv"},
+			want: []envIssue{{1, msg("envEmptyRequired", "GEMINI_API_KEY")}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearRequiredEnv(t)
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			issues, err := validateEnvFile(writeTempFile(t, ".env", tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(issues, tt.want) {
+				t.Errorf("issues = %v, want %v", issues, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunDoctor(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr bool
+	}{
+		{"valid config", map[string]string{".env": requiredEnvBlock, "prompt.txt": "請摘要逐字稿\n"}, false},
+		{"missing .env", map[string]string{"prompt.txt": "請摘要逐字稿\n"}, true},
+		{"invalid .env", map[string]string{".env": "MAX_JOBS = 4\n" + requiredEnvBlock, "prompt.txt": "請摘要逐字稿\n"}, true},
+		{"missing prompt.txt", map[string]string{".env": requiredEnvBlock}, true},
+		{"empty prompt.txt", map[string]string{".env": requiredEnvBlock, "prompt.txt": " \n"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietOutput(t)
+			clearRequiredEnv(t)
+
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := runDoctor(dir); (err != nil) != tt.wantErr {
+				t.Errorf("runDoctor() = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	_ "embed"
+	"errors"
+	"path"
+	"strings"
+)
+
+// executableManifestName 列出需要執行權限之嵌入檔案的清單檔名
+const executableManifestName = "executables.txt"
+
+//go:embed executables.txt
+var executableManifest string
+
+// executableAssets 解析後的執行權限清單（相對路徑），由 loadExecutableManifest 設定
+var executableAssets map[string]bool
+
+// loadExecutableManifest 解析嵌入的執行權限清單，確認每個路徑都存在於嵌入的檔案系統中，
+// 且 scripts/ 下的每個 shell 腳本都已列入清單
+func loadExecutableManifest() error {
+	entries, err := listAllAssets()
+	if err != nil {
+		return err
+	}
+	embedded := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		embedded[entry.relPath] = true
+	}
+
+	executables := make(map[string]bool)
+	for i, line := range strings.Split(executableManifest, "\n") {
+		line = strings.TrimSpace(line)
+
+		// 跳過空行與註解
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if !embedded[line] {
+			return errors.New(msg("unknownExecutableAsset", executableManifestName, i+1, line))
+		}
+		if executables[line] {
+			return errors.New(msg("duplicateExecutableAsset", executableManifestName, i+1, line))
+		}
+		executables[line] = true
+	}
+
+	// 新增腳本卻忘了列入清單時，解壓縮後會變成 0644 而無法執行
+	for _, entry := range entries {
+		if isScriptAsset(entry.relPath) && !executables[entry.relPath] {
+			return errors.New(msg("unlistedScriptAsset", entry.relPath, executableManifestName))
+		}
+	}
+
+	executableAssets = executables
+	return nil
+}
+
+// isScriptAsset 判斷嵌入檔案是否為 scripts/ 下的 shell 腳本
+func isScriptAsset(relPath string) bool {
+	return strings.HasPrefix(relPath, "scripts/") && path.Ext(relPath) == ".sh"
+}
+
+// isExecutableAsset 判斷解壓縮後的檔案是否需要執行權限
+func isExecutableAsset(relPath string) bool {
+	return executableAssets[relPath]
+}
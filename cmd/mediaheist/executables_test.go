@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestLoadExecutableManifestMatchesEmbeddedAssets(t *testing.T) {
+	if err := loadExecutableManifest(); err != nil {
+		t.Fatalf("%s does not match the embedded assets: %v", executableManifestName, err)
+	}
+
+	for _, relPath := range []string{"scripts/select_image", "scripts/common.sh"} {
+		if !isExecutableAsset(relPath) {
+			t.Errorf("isExecutableAsset(%q) = false, want true", relPath)
+		}
+	}
+	if isExecutableAsset("Makefile") {
+		t.Error("isExecutableAsset(\"Makefile\") = true, want false")
+	}
+}
+
+func TestLoadExecutableManifestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+	}{
+		{"unknown path", executableManifest + "scripts/missing.sh\n"},
+		{"duplicate path", executableManifest + "scripts/audio.sh\n"},
+		{"unlisted script", "# 缺少其他腳本\nscripts/select_image\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prevManifest, prevAssets := executableManifest, executableAssets
+			t.Cleanup(func() { executableManifest, executableAssets = prevManifest, prevAssets })
+
+			executableManifest = tt.manifest
+			if err := loadExecutableManifest(); err == nil {
+				t.Error("loadExecutableManifest() returned no error")
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed assets/*
+var embeddedFiles embed.FS
+
+// assetEntry 描述一個嵌入的檔案
+type assetEntry struct {
+	embedPath string // 嵌入檔案系統中的路徑（含 assets/ 前綴）
+	relPath   string // 解壓縮後相對於目標目錄的路徑
+	sha256    string // 嵌入內容的 SHA-256
+}
+
+// listAllAssets 走訪嵌入的檔案系統，列出所有嵌入檔案（不計算雜湊）
+// 錯誤已包含 readManifestFailed 說明，呼叫端不需再包裝
+func listAllAssets() ([]assetEntry, error) {
+	var entries []assetEntry
+
+	err := fs.WalkDir(embeddedFiles, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		entries = append(entries, assetEntry{
+			embedPath: path,
+			relPath:   strings.TrimPrefix(path, "assets/"),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", msg("readManifestFailed"), err)
+	}
+
+	return entries, nil
+}
+
+// listAssets 列出要解壓縮到指定目錄的嵌入檔案（排除 .mediaheistignore 中的樣式）
+func listAssets(dir string) ([]assetEntry, error) {
+	all, err := listAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	patterns, err := loadIgnorePatterns(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []assetEntry
+	for _, entry := range all {
+		if !isIgnoredAsset(entry.relPath, patterns) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+// loadAssetManifest 列出要解壓縮到指定目錄的嵌入檔案並計算內容雜湊
+func loadAssetManifest(dir string) ([]assetEntry, error) {
+	entries, err := listAssets(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range entries {
+		if entries[i].sha256, err = hashEmbeddedFile(entries[i].embedPath); err != nil {
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// hashEmbeddedFile 計算嵌入檔案內容的 SHA-256
+func hashEmbeddedFile(path string) (string, error) {
+	content, err := embeddedFiles.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", msg("readEmbeddedFailed", path), err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// isAlreadyExtracted 檢查是否已經完整解壓縮過 MediaHeist 檔案
+func isAlreadyExtracted(dir string) (bool, error) {
+	missing, err := findMissingAssets(dir)
+	if err != nil {
+		return false, err
+	}
+	return len(missing) == 0, nil
+}
+
+// findMissingAssets 回傳目標目錄中缺少的嵌入檔案（只檢查檔案是否存在）
+func findMissingAssets(dir string) ([]assetEntry, error) {
+	entries, err := listAssets(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []assetEntry
+	for _, entry := range entries {
+		if _, err := os.Stat(filepath.Join(dir, entry.relPath)); os.IsNotExist(err) {
+			missing = append(missing, entry)
+		}
+	}
+	return missing, nil
+}
+
+// findOutdatedFiles 比對目標目錄中的檔案與嵌入內容的雜湊，回傳不一致或缺少的檔案
+func findOutdatedFiles(dir string, manifest []assetEntry) ([]assetEntry, error) {
+	var outdated []assetEntry
+
+	for _, entry := range manifest {
+		sum, err := hashFile(filepath.Join(dir, entry.relPath))
+		if os.IsNotExist(err) {
+			outdated = append(outdated, entry)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", msg("hashFailed", entry.relPath), err)
+		}
+		if sum != entry.sha256 {
+			outdated = append(outdated, entry)
+		}
+	}
+
+	return outdated, nil
+}
+
+// hashFile 計算磁碟上檔案的 SHA-256
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// extractEmbeddedFiles 將嵌入的檔案解壓縮到指定目錄
+func extractEmbeddedFiles(destDir string) error {
+	entries, err := listAssets(destDir)
+	if err != nil {
+		return err
+	}
+	return extractAssets(destDir, entries)
+}
+
+// extractAssets 只解壓縮指定的嵌入檔案
+func extractAssets(destDir string, entries []assetEntry) error {
+	for _, entry := range entries {
+		if err := writeEmbeddedFile(entry.embedPath, destDir); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeEmbeddedFile 將單一嵌入檔案寫入目標目錄，並視需要設定執行權限
+func writeEmbeddedFile(path string, destDir string) error {
+	cleanPath := strings.TrimPrefix(path, "assets/")
+	destPath := filepath.Join(destDir, cleanPath)
+
+	// 讀取嵌入的檔案內容
+	content, err := embeddedFiles.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg("readEmbeddedFailed", path), err)
+	}
+
+	// 確保上層目錄存在
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return fmt.Errorf("%s: %w", msg("mkdirFailed", filepath.Dir(destPath)), err)
+	}
+
+	// 寫入到目標位置
+	if err := os.WriteFile(destPath, content, 0644); err != nil {
+		return fmt.Errorf("%s: %w", msg("writeFailed", destPath), err)
+	}
+
+	// 列在執行權限清單中的腳本與二進制檔案，設定執行權限
+	if isExecutableAsset(cleanPath) {
+		if err := os.Chmod(destPath, 0755); err != nil {
+			return fmt.Errorf("%s: %w", msg("chmodFailed", destPath), err)
+		}
+	}
+	printVerbose("extractedFile", cleanPath)
+
+	return nil
+}
+
+// verifyAssets 列出解壓縮將產生的變更（新增、覆蓋、執行權限），不寫入任何檔案
+func verifyAssets(dir string) error {
+	entries, err := listAllAssets()
+	if err != nil {
+		return err
+	}
+	patterns, err := loadIgnorePatterns(dir)
+	if err != nil {
+		return err
+	}
+
+	var added, overwritten, unchanged, ignored int
+	fmt.Println(msg("verifyHeader", dir))
+	for _, entry := range entries {
+		if isIgnoredAsset(entry.relPath, patterns) {
+			fmt.Printf("  [%s] %s\n", msg("statusIgnored"), entry.relPath)
+			ignored++
+			continue
+		}
+
+		embeddedSum, err := hashEmbeddedFile(entry.embedPath)
+		if err != nil {
+			return err
+		}
+
+		status := msg("statusSame")
+		sum, err := hashFile(filepath.Join(dir, entry.relPath))
+		switch {
+		case os.IsNotExist(err):
+			status = msg("statusNew")
+			added++
+		case err != nil:
+			return fmt.Errorf("%s: %w", msg("hashFailed", entry.relPath), err)
+		case sum != embeddedSum:
+			status = msg("statusOverwrite")
+			overwritten++
+		default:
+			unchanged++
+		}
+
+		mode := "0644"
+		if isExecutableAsset(entry.relPath) {
+			mode = "0755"
+		}
+		fmt.Printf("  [%s] %s %s\n", status, mode, entry.relPath)
+	}
+
+	fmt.Println(msg("verifySummary", len(entries), added, overwritten, unchanged, ignored))
+	if overwritten > 0 {
+		fmt.Println(msg("verifyOverwriteWarn"))
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// quietOutput 在測試期間隱藏啟動器的一般訊息
+func quietOutput(t *testing.T) {
+	t.Helper()
+	prev := launcherOutput
+	launcherOutput = outputQuiet
+	t.Cleanup(func() { launcherOutput = prev })
+}
+
+// newExtractedDir 建立已完整解壓縮所有嵌入檔案的暫存目錄
+func newExtractedDir(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := extractEmbeddedFiles(dir); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// backdateFiles 將目錄中所有檔案的修改時間設為過去，用來偵測檔案是否被重新寫入
+func backdateFiles(t *testing.T, dir string) time.Time {
+	t.Helper()
+	old := time.Now().Add(-time.Hour).Truncate(time.Second)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return old
+}
+
+// rewrittenAssets 列出修改時間晚於 since 的嵌入檔案（即被重新寫入的檔案）
+func rewrittenAssets(t *testing.T, dir string, since time.Time) []string {
+	t.Helper()
+	entries, err := listAllAssets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rewritten []string
+	for _, entry := range entries {
+		info, err := os.Stat(filepath.Join(dir, entry.relPath))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.ModTime().After(since) {
+			rewritten = append(rewritten, entry.relPath)
+		}
+	}
+	return rewritten
+}
+
+// assertMatchesEmbedded 確認目錄中的每個嵌入檔案都與嵌入內容一致
+func assertMatchesEmbedded(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := listAllAssets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, entry := range entries {
+		want, err := embeddedFiles.ReadFile(entry.embedPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, entry.relPath))
+		if err != nil {
+			t.Errorf("%s: %v", entry.relPath, err)
+			continue
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s differs from the embedded content", entry.relPath)
+		}
+	}
+}
+
+func TestFindMissingAssets(t *testing.T) {
+	tests := []struct {
+		name   string
+		remove []string
+		want   []string
+	}{
+		{"fully extracted", nil, nil},
+		{"one file missing", []string{"scripts/audio.sh"}, []string{"scripts/audio.sh"}},
+		{"two files missing", []string{"Makefile", "scripts/frames.sh"}, []string{"Makefile", "scripts/frames.sh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := newExtractedDir(t)
+			for _, name := range tt.remove {
+				if err := os.Remove(filepath.Join(dir, name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			missing, err := findMissingAssets(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, entry := range missing {
+				got = append(got, entry.relPath)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("missing = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindMissingAssetsEmptyDir(t *testing.T) {
+	entries, err := listAllAssets()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	missing, err := findMissingAssets(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != len(entries) {
+		t.Errorf("missing %d files, want all %d", len(missing), len(entries))
+	}
+}
+
+func TestFindOutdatedFiles(t *testing.T) {
+	sum := func(s string) string {
+		h := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(h[:])
+	}
+
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"same.txt":        "hello",
+		"changed.txt":     "edited by user",
+		"nested/same.txt": "nested",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	manifest := []assetEntry{
+		{relPath: "same.txt", sha256: sum("hello")},
+		{relPath: "changed.txt", sha256: sum("original")},
+		{relPath: "missing.txt", sha256: sum("missing")},
+		{relPath: "nested/same.txt", sha256: sum("nested")},
+	}
+
+	outdated, err := findOutdatedFiles(dir, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range outdated {
+		got = append(got, entry.relPath)
+	}
+	if want := []string{"changed.txt", "missing.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("outdated = %q, want %q", got, want)
+	}
+}
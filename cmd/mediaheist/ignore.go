@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreFileName 工作目錄中列出不需解壓縮之嵌入檔案的設定檔
+const ignoreFileName = ".mediaheistignore"
+
+// loadIgnorePatterns 讀取工作目錄中的 .mediaheistignore，檔案不存在時回傳空清單
+func loadIgnorePatterns(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, ignoreFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", msg("readIgnoreFailed", ignoreFileName), err)
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+
+		// 跳過空行與註解
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// 與 .gitignore 相同，開頭的 / 表示相對於根目錄，結尾的 / 表示目錄
+		pattern := strings.Trim(line, "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, errors.New(msg("badIgnorePattern", ignoreFileName, lineNo, line))
+		}
+		patterns = append(patterns, pattern)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", msg("readIgnoreFailed", ignoreFileName), err)
+	}
+
+	return patterns, nil
+}
+
+// printIgnoredAssets 在 --verbose 時列出被 .mediaheistignore 排除的嵌入檔案
+func printIgnoredAssets(dir string) error {
+	if launcherOutput < outputVerbose {
+		return nil
+	}
+
+	entries, err := listAllAssets()
+	if err != nil {
+		return err
+	}
+	patterns, err := loadIgnorePatterns(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if isIgnoredAsset(entry.relPath, patterns) {
+			printVerbose("ignoredFile", entry.relPath)
+		}
+	}
+	return nil
+}
+
+// isIgnoredAsset 判斷解壓縮後的相對路徑是否符合任一忽略樣式
+func isIgnoredAsset(relPath string, patterns []string) bool {
+	for _, pattern := range patterns {
+		// 比對完整路徑以及每一層上層目錄（忽略目錄即忽略其下所有檔案）
+		for p := relPath; p != "."; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+
+		// 不含 / 的樣式也比對檔名，例如 *.sh
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(relPath)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
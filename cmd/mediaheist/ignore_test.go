@@ -0,0 +1,75 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestLoadIgnorePatterns(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+		wantErr bool
+	}{
+		{"comments and blank lines", "# comment\n\n  Makefile  \n", []string{"Makefile"}, false},
+		{"leading and trailing slashes", "/scripts/\n/Makefile\n", []string{"scripts", "Makefile"}, false},
+		{"glob pattern", "scripts/*.sh\n", []string{"scripts/*.sh"}, false},
+		{"bad pattern", "Makefile\nscripts/[\n", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, ignoreFileName), []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := loadIgnorePatterns(dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("patterns = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadIgnorePatternsMissingFile(t *testing.T) {
+	got, err := loadIgnorePatterns(t.TempDir())
+	if err != nil || got != nil {
+		t.Errorf("loadIgnorePatterns() = %q, %v, want nil, nil", got, err)
+	}
+}
+
+func TestIsIgnoredAsset(t *testing.T) {
+	tests := []struct {
+		name     string
+		relPath  string
+		patterns []string
+		want     bool
+	}{
+		{"no patterns", "Makefile", nil, false},
+		{"exact path", "Makefile", []string{"Makefile"}, true},
+		{"nested exact path", "scripts/audio.sh", []string{"scripts/audio.sh"}, true},
+		{"directory", "scripts/audio.sh", []string{"scripts"}, true},
+		{"glob in directory", "scripts/audio.sh", []string{"scripts/*.sh"}, true},
+		{"basename glob", "scripts/audio.sh", []string{"*.sh"}, true},
+		{"basename", "scripts/select_image", []string{"select_image"}, true},
+		{"path pattern does not match basename", "scripts/audio.sh", []string{"other/audio.sh"}, false},
+		{"no partial match", "scripts/audio.sh", []string{"audio"}, false},
+		{"directory prefix is not a match", "scripts_old/audio.sh", []string{"scripts"}, false},
+		{"any pattern matches", "Makefile", []string{"scripts", "Make*"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isIgnoredAsset(tt.relPath, tt.patterns); got != tt.want {
+				t.Errorf("isIgnoredAsset(%q, %q) = %v, want %v", tt.relPath, tt.patterns, got, tt.want)
+			}
+		})
+	}
+}
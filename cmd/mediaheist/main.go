@@ -1,69 +1,147 @@
 package main
 
 import (
-	"embed"
+	"errors"
 	"fmt"
-	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"syscall"
 )
 
-//go:embed assets/*
-var embeddedFiles embed.FS
-
 const (
 	tempDirPrefix = "mediaheist-"
+
+	// exitMakeNotFound 找不到 make 時的結束碼（沿用 shell 的 command not found 慣例）
+	exitMakeNotFound = 127
 )
 
+// outputLevel 啟動器自身訊息的輸出等級（不影響 make 的輸出）
+type outputLevel int
+
+const (
+	outputQuiet outputLevel = iota
+	outputNormal
+	outputVerbose
+)
+
+// launcherOutput 目前的輸出等級
+var launcherOutput = outputNormal
+
+// launcherOptions 啟動器本身的參數（不會傳遞給 make）
+type launcherOptions struct {
+	forceExtract bool   // 無條件重新解壓縮並覆蓋所有檔案
+	workDir      string // 指定的工作目錄（空字串表示使用當前目錄）
+	quiet        bool   // 隱藏啟動器的一般資訊
+	verbose      bool   // 顯示啟動器的詳細資訊
+	printConfig  bool   // 只列出解析後的配置，不解壓縮也不執行 make
+}
+
+// launcherCommand 由啟動器自行處理、不會執行 make 的子命令
+type launcherCommand func(dir string, opts launcherOptions) error
+
+// launcherCommands 啟動器子命令（main 與 --print-config 共用此清單）
+var launcherCommands = map[string]launcherCommand{
+	// verify：只列出解壓縮將產生的變更
+	"verify": func(dir string, _ launcherOptions) error {
+		return verifyAssets(dir)
+	},
+	// doctor：只檢查配置檔案
+	"doctor": func(dir string, _ launcherOptions) error {
+		return runDoctor(dir)
+	},
+	// targets：列出解壓縮後 Makefile 中的目標
+	"targets": func(dir string, opts launcherOptions) error {
+		if err := prepareAssets(dir, opts.forceExtract); err != nil {
+			return err
+		}
+		return printTargets(filepath.Join(dir, "Makefile"))
+	},
+}
+
 func main() {
-	// 處理 --help 參數
-	if len(os.Args) > 1 && (os.Args[1] == "--help" || os.Args[1] == "-h" || os.Args[1] == "help") {
+	// 決定訊息語系（需在解析其他參數前完成，錯誤訊息才能正確翻譯）
+	currentLang = detectLanguage(os.Args[1:])
+
+	// 分離啟動器參數與 make 參數
+	opts, makeArgs, err := parseLauncherArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, msg("error", err))
+		os.Exit(1)
+	}
+	if opts.quiet {
+		launcherOutput = outputQuiet
+	} else if opts.verbose {
+		launcherOutput = outputVerbose
+	}
+
+	// 處理 --help 參數（--print-config 時改由 printConfig 回報）
+	if !opts.printConfig && len(makeArgs) > 0 && isHelpArg(makeArgs[0]) {
 		showHelp()
 		return
 	}
 
-	// 取得當前工作目錄
-	currentDir, err := os.Getwd()
+	// 確認嵌入的執行權限清單與嵌入檔案一致
+	if err := loadExecutableManifest(); err != nil {
+		fmt.Fprintln(os.Stderr, msg("error", err))
+		os.Exit(1)
+	}
+
+	// 取得工作目錄（預設為當前目錄）
+	workDir, err := resolveWorkDir(opts.workDir)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "錯誤：無法取得當前目錄: %v\n", err)
+		fmt.Fprintln(os.Stderr, msg("error", err))
 		os.Exit(1)
 	}
+	printVerbose("workDirInfo", workDir)
 
-	// 檢查是否已經解壓縮過（避免重複解壓縮）
-	if !isAlreadyExtracted(currentDir) {
-		fmt.Println("ℹ️ 正在解壓縮 MediaHeist 檔案到當前目錄...")
-		// 解壓縮嵌入的檔案到當前目錄
-		if err := extractEmbeddedFiles(currentDir); err != nil {
-			fmt.Fprintf(os.Stderr, "錯誤：解壓縮檔案失敗: %v\n", err)
+	// 處理 --print-config 參數：只列出解析後的配置
+	if opts.printConfig {
+		if err := printConfig(workDir, opts, makeArgs); err != nil {
+			fmt.Fprintln(os.Stderr, msg("error", err))
 			os.Exit(1)
 		}
-		fmt.Println("✓ 檔案解壓縮完成")
-	} else {
-		fmt.Println("✓ 檢測到已存在的 MediaHeist 檔案，跳過解壓縮")
+		return
+	}
+
+	// 啟動器子命令（不需要執行 make）
+	if len(makeArgs) > 0 {
+		if run := launcherCommands[makeArgs[0]]; run != nil {
+			if err := run(workDir, opts); err != nil {
+				fmt.Fprintln(os.Stderr, msg("error", err))
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	// 確認系統已安裝 make（避免解壓縮後才發現無法執行）
+	ensureMakeAvailable()
+
+	// 檢查是否已經解壓縮過（避免重複解壓縮）
+	if err := prepareAssets(workDir, opts.forceExtract); err != nil {
+		fmt.Fprintln(os.Stderr, msg("error", err))
+		os.Exit(1)
 	}
 
 	// 檢查配置檔案
-	checkConfigFiles(currentDir)
+	checkConfigFiles(workDir)
+	checkEnvFile(workDir)
+	checkPromptFile(workDir)
 
 	// 準備 make 命令參數
-	args := []string{"make"}
-	if len(os.Args) > 1 {
-		args = append(args, os.Args[1:]...)
-	} else {
-		// 如果沒有參數，顯示幫助資訊
-		args = append(args, "help")
-	}
+	args := buildMakeArgs(makeArgs)
+	printVerbose("runningCommand", shellJoin(args))
 
-	// 執行 make 命令（在當前目錄）
+	// 執行 make 命令（在工作目錄）
 	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	cmd.Env = os.Environ()
-	cmd.Dir = currentDir // 確保在當前目錄執行
+	cmd.Dir = workDir // 確保在工作目錄執行
 
 	if err := cmd.Run(); err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
@@ -71,166 +149,276 @@ func main() {
 				os.Exit(status.ExitStatus())
 			}
 		}
-		fmt.Fprintf(os.Stderr, "錯誤：執行 make 失敗: %v\n", err)
+		fmt.Fprintln(os.Stderr, msg("error", fmt.Errorf("%s: %w", msg("makeRunFailed"), err)))
 		os.Exit(1)
 	}
 }
 
-// isAlreadyExtracted 檢查是否已經解壓縮過 MediaHeist 檔案
-func isAlreadyExtracted(dir string) bool {
-	// 檢查關鍵檔案是否存在
-	makefilePath := filepath.Join(dir, "Makefile")
-	scriptsPath := filepath.Join(dir, "scripts")
+// parseLauncherArgs 從命令列參數中取出啟動器參數，其餘原樣交給 make
+func parseLauncherArgs(args []string) (launcherOptions, []string, error) {
+	var opts launcherOptions
+	var makeArgs []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--force-extract":
+			opts.forceExtract = true
+		case arg == "--quiet":
+			opts.quiet = true
+		case arg == "--verbose":
+			opts.verbose = true
+		case arg == "--print-config":
+			opts.printConfig = true
+		case arg == "--workdir" || arg == "-C":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return opts, nil, errors.New(msg("flagNeedsValue", arg))
+			}
+			i++
+			opts.workDir = args[i]
+		case strings.HasPrefix(arg, "--workdir="):
+			opts.workDir = strings.TrimPrefix(arg, "--workdir=")
+			if opts.workDir == "" {
+				return opts, nil, errors.New(msg("flagNeedsValue", "--workdir"))
+			}
+		case strings.HasPrefix(arg, "-C"):
+			// 與 make 相同，接受 -C<dir> 的寫法（否則 make 會在其他目錄執行）
+			opts.workDir = strings.TrimPrefix(arg, "-C")
+		case arg == "--lang":
+			// 語系已由 detectLanguage 處理，這裡只負責移除參數
+			if i+1 >= len(args) {
+				return opts, nil, errors.New(msg("flagNeedsValue", arg))
+			}
+			i++
+		case strings.HasPrefix(arg, "--lang="):
+		default:
+			makeArgs = append(makeArgs, arg)
+		}
+	}
 
-	// 檢查 Makefile 和 scripts 目錄是否都存在
-	if _, err := os.Stat(makefilePath); os.IsNotExist(err) {
-		return false
+	if opts.quiet && opts.verbose {
+		return opts, nil, errors.New(msg("quietVerboseConflict"))
 	}
-	if _, err := os.Stat(scriptsPath); os.IsNotExist(err) {
-		return false
+
+	return opts, makeArgs, nil
+}
+
+// buildMakeArgs 組成要執行的 make 命令（含命令名稱）
+func buildMakeArgs(makeArgs []string) []string {
+	args := []string{"make"}
+	if len(makeArgs) > 0 {
+		return append(args, makeArgs...)
 	}
+	// 如果沒有參數，顯示幫助資訊
+	return append(args, "help")
+}
 
-	return true
+// printInfo 輸出啟動器的一般資訊（--quiet 時隱藏）
+func printInfo(key string, a ...any) {
+	if launcherOutput >= outputNormal {
+		fmt.Println(msg(key, a...))
+	}
 }
 
-// checkConfigFiles 檢查配置檔案狀態並顯示資訊
-func checkConfigFiles(dir string) {
-	var foundFiles []string
-	var missingFiles []string
+// printWarn 輸出啟動器的警告（一律寫入 stderr，stdout 只保留給 make 與子命令的輸出）
+func printWarn(key string, a ...any) {
+	fmt.Fprintln(os.Stderr, msg(key, a...))
+}
 
-	// 要檢查的配置檔案
-	configFiles := map[string]string{
-		".env":       "環境變數配置（必需）",
-		"prompt.txt": "自定義提示詞（可選）",
+// printVerbose 輸出啟動器的詳細資訊（僅 --verbose 時顯示）
+func printVerbose(key string, a ...any) {
+	if launcherOutput >= outputVerbose {
+		fmt.Println(msg(key, a...))
 	}
+}
 
-	for filename, description := range configFiles {
-		filePath := filepath.Join(dir, filename)
-		if _, err := os.Stat(filePath); err == nil {
-			foundFiles = append(foundFiles, fmt.Sprintf("%s (%s)", filename, description))
-		} else {
-			missingFiles = append(missingFiles, fmt.Sprintf("%s (%s)", filename, description))
+// resolveWorkDir 解析工作目錄為絕對路徑，未指定時使用當前目錄
+func resolveWorkDir(dir string) (string, error) {
+	if dir == "" {
+		currentDir, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", msg("getwdFailed"), err)
 		}
+		return currentDir, nil
 	}
 
-	// 顯示找到的配置檔案
-	if len(foundFiles) > 0 {
-		fmt.Printf("✓ 找到配置檔案: %s\n", strings.Join(foundFiles, ", "))
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", msg("workDirResolveFailed", dir), err)
 	}
 
-	// 顯示缺少的配置檔案
-	if len(missingFiles) > 0 {
-		fmt.Printf("⚠️  缺少配置檔案: %s\n", strings.Join(missingFiles, ", "))
-
-		// 如果缺少 .env，顯示警告
-		if _, err := os.Stat(filepath.Join(dir, ".env")); os.IsNotExist(err) {
-			fmt.Println("⚠️  警告: .env 檔案不存在，可能會導致執行失敗")
-			fmt.Println("   請在當前目錄建立 .env 檔案並設定必要的環境變數")
-		}
+	info, err := os.Stat(absDir)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", msg("workDirNotExist", absDir), err)
 	}
+	if !info.IsDir() {
+		return "", errors.New(msg("workDirNotDir", absDir))
+	}
+
+	return absDir, nil
 }
 
-// showHelp 顯示幫助資訊
-func showHelp() {
-	fmt.Print(`MediaHeist - 媒體處理工具包
-
-使用方式:
-  mediaheist <target> [參數...]
-
-常用目標:
-  download URL="<url>"              下載並處理單一媒體
-  download LIST="<file>"            批次處理媒體列表
-  all LIST="<file>" MAX_JOBS=<n>    平行處理所有步驟
-  transcribe                        僅執行轉錄步驟
-  frames                           僅執行影格擷取
-  summary                          僅執行摘要生成
-  clean                            清理暫存檔案
-  help                             顯示 Makefile 說明
-
-支援的輸入格式:
-  - YouTube URLs: https://www.youtube.com/watch?v=VIDEO_ID
-  - YouTube 短網址: https://youtu.be/VIDEO_ID
-  - YouTube 影片 ID: VIDEO_ID (11 字元)
-  - 本地檔案路徑: /absolute/path/to/video.mp4
-
-配置檔案設定:
-  請在執行 mediaheist 的目錄下放置以下檔案：
-  
-  .env - 環境變數配置（必需）:
-    GEMINI_API_KEY=your_gemini_api_key
-    GEMINI_MODEL_ID=gemini-1.5-flash
-    WHISPER_BIN=/usr/local/bin/whisper
-    WHISPER_MODEL=base
-  
-  prompt.txt - 自定義提示詞（可選）:
-    用於自定義 AI 摘要生成的提示詞模板
-
-執行方式:
-  - 程式會自動將 Makefile 和 scripts 解壓縮到當前目錄
-  - 所有產生的檔案（下載、轉錄、摘要等）都會在當前目錄
-  - 配置檔案直接從當前目錄讀取，無需複製
-
-除錯資訊:
-  - 如果出現 "Missing required variables" 錯誤，請檢查:
-    1. .env 檔案是否存在於當前目錄
-    2. .env 檔案格式是否正確（KEY=VALUE，無空格）
-    3. 所有必需變數是否都已設定
-  - 執行時會顯示找到的配置檔案清單
-  - 首次執行會解壓縮檔案，之後會自動跳過
-
-範例:
-  # 在任意目錄下建立 .env 檔案
-  echo "GEMINI_API_KEY=your_key" > .env
-  echo "GEMINI_MODEL_ID=gemini-1.5-flash" >> .env
-  echo "WHISPER_BIN=/usr/local/bin/whisper" >> .env
-  echo "WHISPER_MODEL=base" >> .env
-  
-  # 執行 MediaHeist
-  mediaheist download URL="https://youtu.be/dQw4w9WgXcQ"
-  mediaheist download URL="dQw4w9WgXcQ"
-  mediaheist download LIST="urls.txt"
-  mediaheist all LIST="batch.txt" MAX_JOBS=4
-`)
-}
-
-// extractEmbeddedFiles 將嵌入的檔案解壓縮到指定目錄
-func extractEmbeddedFiles(destDir string) error {
-	return fs.WalkDir(embeddedFiles, ".", func(path string, d fs.DirEntry, err error) error {
+// prepareAssets 確保工作目錄中的 MediaHeist 檔案完整且與目前版本一致
+func prepareAssets(dir string, force bool) error {
+	if err := printIgnoredAssets(dir); err != nil {
+		return err
+	}
+
+	if !force {
+		extracted, err := isAlreadyExtracted(dir)
 		if err != nil {
 			return err
 		}
-
-		// 跳過根目錄
-		if path == "." {
-			return nil
+		if extracted {
+			return syncOutdatedFiles(dir)
 		}
+	}
 
-		// 移除 "assets/" 前綴
-		cleanPath := strings.TrimPrefix(path, "assets/")
-		destPath := filepath.Join(destDir, cleanPath)
+	entries, err := listAssets(dir)
+	if err != nil {
+		return err
+	}
+	missing, err := findMissingAssets(dir)
+	if err != nil {
+		return err
+	}
 
-		if d.IsDir() {
-			return os.MkdirAll(destPath, 0755)
+	// 上次解壓縮中斷：只補齊缺少的檔案，再比對其餘檔案
+	if !force && len(missing) < len(entries) {
+		var names []string
+		for _, entry := range missing {
+			names = append(names, entry.relPath)
 		}
+		printWarn("partialExtraction", strings.Join(names, ", "))
 
-		// 讀取嵌入的檔案內容
-		content, err := embeddedFiles.ReadFile(path)
-		if err != nil {
-			return fmt.Errorf("讀取嵌入檔案 %s 失敗: %w", path, err)
+		if err := extractAssets(dir, missing); err != nil {
+			return fmt.Errorf("%s: %w", msg("extractFailed"), err)
 		}
+		return syncOutdatedFiles(dir)
+	}
+
+	printInfo("extracting")
+	// 解壓縮嵌入的檔案到工作目錄
+	if err := extractEmbeddedFiles(dir); err != nil {
+		return fmt.Errorf("%s: %w", msg("extractFailed"), err)
+	}
+	printInfo("extractDone")
+	return nil
+}
+
+// syncOutdatedFiles 比對已存在的檔案與嵌入版本，重新解壓縮內容不一致的檔案
+func syncOutdatedFiles(dir string) error {
+	manifest, err := loadAssetManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	outdated, err := findOutdatedFiles(dir, manifest)
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg("checkExistingFailed"), err)
+	}
+
+	if len(outdated) == 0 {
+		printInfo("upToDate")
+		return nil
+	}
+
+	var names []string
+	for _, entry := range outdated {
+		names = append(names, entry.relPath)
+	}
+	printWarn("outdatedFiles", strings.Join(names, ", "))
+
+	if err := extractAssets(dir, outdated); err != nil {
+		return fmt.Errorf("%s: %w", msg("extractFailed"), err)
+	}
+	printInfo("updateDone")
+	return nil
+}
+
+// ensureMakeAvailable 確認 PATH 中可以找到 make，找不到時顯示安裝提示並結束程式
+func ensureMakeAvailable() {
+	if _, err := exec.LookPath("make"); err == nil {
+		return
+	}
 
-		// 寫入到目標位置
-		if err := os.WriteFile(destPath, content, 0644); err != nil {
-			return fmt.Errorf("寫入檔案 %s 失敗: %w", destPath, err)
+	fmt.Fprintln(os.Stderr, msg("makeNotFound"))
+
+	// 部分系統（例如 BSD）只提供 gmake
+	if gmakePath, err := exec.LookPath("gmake"); err == nil {
+		fmt.Fprintln(os.Stderr, msg("gmakeFound", gmakePath))
+		fmt.Fprintln(os.Stderr, msg("gmakeLinkHint"))
+		fmt.Fprintf(os.Stderr, "     ln -s %s /usr/local/bin/make\n", gmakePath)
+		os.Exit(exitMakeNotFound)
+	}
+
+	fmt.Fprintln(os.Stderr, msg("installMake"))
+	switch runtime.GOOS {
+	case "darwin":
+		fmt.Fprintln(os.Stderr, "     xcode-select --install")
+		fmt.Fprintln(os.Stderr, msg("installMakeBrew"))
+	case "linux":
+		fmt.Fprintln(os.Stderr, "     Debian/Ubuntu: sudo apt-get install make")
+		fmt.Fprintln(os.Stderr, "     Fedora/RHEL:   sudo dnf install make")
+		fmt.Fprintln(os.Stderr, "     Alpine:        apk add make")
+	case "windows":
+		fmt.Fprintln(os.Stderr, msg("installMakeWSL"))
+		fmt.Fprintln(os.Stderr, msg("installMakeMSYS2"))
+	default:
+		fmt.Fprintln(os.Stderr, msg("installMakeOther"))
+	}
+	os.Exit(exitMakeNotFound)
+}
+
+// scanConfigFiles 檢查工作目錄中的配置檔案，回傳已找到與缺少的檔案說明
+func scanConfigFiles(dir string) (foundFiles, missingFiles []string) {
+	// 要檢查的配置檔案
+	configFiles := []struct {
+		name        string
+		description string
+	}{
+		{".env", msg("envDescription")},
+		{"prompt.txt", msg("promptDescription")},
+	}
+
+	for _, file := range configFiles {
+		label := fmt.Sprintf("%s (%s)", file.name, file.description)
+		if _, err := os.Stat(filepath.Join(dir, file.name)); err == nil {
+			foundFiles = append(foundFiles, label)
+		} else {
+			missingFiles = append(missingFiles, label)
 		}
+	}
+	return foundFiles, missingFiles
+}
 
-		// 如果是 shell 腳本或特定檔案，設定執行權限
-		if strings.HasSuffix(cleanPath, ".sh") || strings.Contains(cleanPath, "scripts/select_image") {
-			if err := os.Chmod(destPath, 0755); err != nil {
-				return fmt.Errorf("設定執行權限失敗 %s: %w", destPath, err)
-			}
+// checkConfigFiles 檢查配置檔案狀態並顯示資訊
+func checkConfigFiles(dir string) {
+	foundFiles, missingFiles := scanConfigFiles(dir)
+
+	// 顯示找到的配置檔案
+	if len(foundFiles) > 0 {
+		printInfo("configFound", strings.Join(foundFiles, ", "))
+	}
+
+	// 顯示缺少的配置檔案
+	if len(missingFiles) > 0 {
+		printInfo("configMissing", strings.Join(missingFiles, ", "))
+
+		// 如果缺少 .env，顯示警告
+		if _, err := os.Stat(filepath.Join(dir, ".env")); os.IsNotExist(err) {
+			printWarn("envMissingWarn")
+			printWarn("envMissingHint")
 		}
+	}
+}
 
-		return nil
-	})
+// isHelpArg 判斷參數是否要求顯示啟動器幫助資訊（不執行 make）
+func isHelpArg(arg string) bool {
+	return arg == "--help" || arg == "-h" || arg == "help"
+}
+
+// showHelp 顯示幫助資訊
+func showHelp() {
+	fmt.Print(msg("help"))
 }
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestMain 與 main 相同，先載入執行權限清單，解壓縮測試才會設定正確的權限
+func TestMain(m *testing.M) {
+	if err := loadExecutableManifest(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(m.Run())
+}
+
+func TestParseLauncherArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantOpts launcherOptions
+		wantMake []string
+		wantErr  bool
+	}{
+		{
+			name:     "no args",
+			args:     nil,
+			wantMake: nil,
+		},
+		{
+			name:     "make args pass through",
+			args:     []string{"download", "URL=a b", "-j4", "-v"},
+			wantMake: []string{"download", "URL=a b", "-j4", "-v"},
+		},
+		{
+			name:     "workdir short flag",
+			args:     []string{"-C", "/tmp/work", "all", "MAX_JOBS=4"},
+			wantOpts: launcherOptions{workDir: "/tmp/work"},
+			wantMake: []string{"all", "MAX_JOBS=4"},
+		},
+		{
+			name:     "workdir long flag with equals",
+			args:     []string{"all", "--workdir=/tmp/work"},
+			wantOpts: launcherOptions{workDir: "/tmp/work"},
+			wantMake: []string{"all"},
+		},
+		{
+			name:     "workdir anywhere in argv",
+			args:     []string{"download", "--workdir", "/tmp/work", "URL=x"},
+			wantOpts: launcherOptions{workDir: "/tmp/work"},
+			wantMake: []string{"download", "URL=x"},
+		},
+		{
+			name:     "workdir attached to short flag",
+			args:     []string{"-C/tmp/work", "all"},
+			wantOpts: launcherOptions{workDir: "/tmp/work"},
+			wantMake: []string{"all"},
+		},
+		{
+			name:    "workdir without value",
+			args:    []string{"all", "-C"},
+			wantErr: true,
+		},
+		{
+			name:    "workdir with empty value",
+			args:    []string{"-C", "", "all"},
+			wantErr: true,
+		},
+		{
+			name:    "workdir long flag with empty value",
+			args:    []string{"--workdir=", "all"},
+			wantErr: true,
+		},
+		{
+			name:     "force extract",
+			args:     []string{"download", "--force-extract", "URL=x"},
+			wantOpts: launcherOptions{forceExtract: true},
+			wantMake: []string{"download", "URL=x"},
+		},
+		{
+			name:     "quiet",
+			args:     []string{"--quiet", "targets"},
+			wantOpts: launcherOptions{quiet: true},
+			wantMake: []string{"targets"},
+		},
+		{
+			name:     "verbose",
+			args:     []string{"download", "--verbose"},
+			wantOpts: launcherOptions{verbose: true},
+			wantMake: []string{"download"},
+		},
+		{
+			name:    "quiet and verbose conflict",
+			args:    []string{"--quiet", "--verbose"},
+			wantErr: true,
+		},
+		{
+			name:     "lang is stripped",
+			args:     []string{"--lang", "en", "download", "--lang=zh-TW"},
+			wantMake: []string{"download"},
+		},
+		{
+			name:    "lang without value",
+			args:    []string{"--lang"},
+			wantErr: true,
+		},
+		{
+			name:     "print config",
+			args:     []string{"all", "--print-config", "MAX_JOBS=4"},
+			wantOpts: launcherOptions{printConfig: true},
+			wantMake: []string{"all", "MAX_JOBS=4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, makeArgs, err := parseLauncherArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if opts != tt.wantOpts {
+				t.Errorf("opts = %+v, want %+v", opts, tt.wantOpts)
+			}
+			if !reflect.DeepEqual(makeArgs, tt.wantMake) {
+				t.Errorf("makeArgs = %q, want %q", makeArgs, tt.wantMake)
+			}
+		})
+	}
+}
+
+func TestPrepareAssetsRepairsPartialExtraction(t *testing.T) {
+	quietOutput(t)
+	dir := newExtractedDir(t)
+
+	// 模擬中斷的解壓縮：缺少一個檔案，另一個檔案內容過期
+	if err := os.Remove(filepath.Join(dir, "scripts", "frames.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Makefile"), []byte("stale"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	since := backdateFiles(t, dir)
+
+	if err := prepareAssets(dir, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 只補齊缺少的檔案，並由同步步驟更新過期的檔案，其餘檔案不會重寫
+	got := rewrittenAssets(t, dir, since)
+	if want := []string{"Makefile", "scripts/frames.sh"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("rewritten = %q, want %q", got, want)
+	}
+	assertMatchesEmbedded(t, dir)
+
+	info, err := os.Stat(filepath.Join(dir, "scripts", "frames.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("scripts/frames.sh mode = %v, want 0755", info.Mode().Perm())
+	}
+}
+
+func TestIsAlreadyExtracted(t *testing.T) {
+	dir := newExtractedDir(t)
+	if ok, err := isAlreadyExtracted(dir); err != nil || !ok {
+		t.Errorf("isAlreadyExtracted(full) = %v, %v, want true, nil", ok, err)
+	}
+
+	if err := os.Remove(filepath.Join(dir, "Makefile")); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := isAlreadyExtracted(dir); err != nil || ok {
+		t.Errorf("isAlreadyExtracted(partial) = %v, %v, want false, nil", ok, err)
+	}
+
+	// .mediaheistignore 錯誤必須回報，而不是當成尚未解壓縮
+	if err := os.WriteFile(filepath.Join(dir, ignoreFileName), []byte("scripts/[\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := isAlreadyExtracted(dir); err == nil {
+		t.Error("isAlreadyExtracted with a bad ignore pattern returned no error")
+	}
+}
+
+func TestPrepareAssets(t *testing.T) {
+	tests := []struct {
+		name          string
+		setup         func(t *testing.T, dir string)
+		force         bool
+		wantRewritten []string // 應重新寫入的嵌入檔案
+		wantAll       bool     // 所有嵌入檔案都應重新寫入
+	}{
+		{
+			name:    "empty directory",
+			setup:   func(t *testing.T, dir string) {},
+			wantAll: true,
+		},
+		{
+			name: "unchanged files are left alone",
+			setup: func(t *testing.T, dir string) {
+				if err := extractEmbeddedFiles(dir); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantRewritten: []string{},
+		},
+		{
+			name: "modified file is restored",
+			setup: func(t *testing.T, dir string) {
+				if err := extractEmbeddedFiles(dir); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(dir, "scripts", "audio.sh"), []byte("edited"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantRewritten: []string{"scripts/audio.sh"},
+		},
+		{
+			name: "missing file is restored",
+			setup: func(t *testing.T, dir string) {
+				if err := extractEmbeddedFiles(dir); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Remove(filepath.Join(dir, "Makefile")); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantRewritten: []string{"Makefile"},
+		},
+		{
+			name: "force extract rewrites unchanged files",
+			setup: func(t *testing.T, dir string) {
+				if err := extractEmbeddedFiles(dir); err != nil {
+					t.Fatal(err)
+				}
+			},
+			force:   true,
+			wantAll: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quietOutput(t)
+			dir := t.TempDir()
+			tt.setup(t, dir)
+
+			// 使用者自己的檔案不應被動到
+			envPath := filepath.Join(dir, ".env")
+			if err := os.WriteFile(envPath, []byte("MAX_JOBS=4\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			since := backdateFiles(t, dir)
+
+			if err := prepareAssets(dir, tt.force); err != nil {
+				t.Fatal(err)
+			}
+
+			assertMatchesEmbedded(t, dir)
+			if env, err := os.ReadFile(envPath); err != nil || string(env) != "MAX_JOBS=4\n" {
+				t.Errorf(".env = %q, %v, want it untouched", env, err)
+			}
+
+			got := rewrittenAssets(t, dir, since)
+			if tt.wantAll {
+				entries, err := listAllAssets()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(got) != len(entries) {
+					t.Errorf("rewritten = %q, want all %d files", got, len(entries))
+				}
+				return
+			}
+			if got == nil {
+				got = []string{}
+			}
+			if !reflect.DeepEqual(got, tt.wantRewritten) {
+				t.Errorf("rewritten = %q, want %q", got, tt.wantRewritten)
+			}
+		})
+	}
+}
@@ -0,0 +1,594 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 支援的語系
+const (
+	langZhTW = "zh-TW"
+	langEn   = "en"
+
+	// defaultLang 無法辨識語系時使用的預設語系
+	defaultLang = langZhTW
+)
+
+// currentLang 目前使用的語系
+var currentLang = defaultLang
+
+// messages 訊息目錄：訊息代碼 -> 語系 -> 格式字串
+var messages = map[string]map[string]string{
+	// 一般錯誤
+	"error": {
+		langZhTW: "錯誤：%v",
+		langEn:   "Error: %v",
+	},
+	"flagNeedsValue": {
+		langZhTW: "%s 需要指定值",
+		langEn:   "%s requires a value",
+	},
+	"quietVerboseConflict": {
+		langZhTW: "--quiet 與 --verbose 不能同時使用",
+		langEn:   "--quiet and --verbose cannot be used together",
+	},
+	"makeRunFailed": {
+		langZhTW: "執行 make 失敗",
+		langEn:   "failed to run make",
+	},
+
+	// 工作目錄
+	"getwdFailed": {
+		langZhTW: "無法取得當前目錄",
+		langEn:   "cannot determine current directory",
+	},
+	"workDirResolveFailed": {
+		langZhTW: "無法解析工作目錄 %s",
+		langEn:   "cannot resolve working directory %s",
+	},
+	"workDirNotExist": {
+		langZhTW: "工作目錄 %s 不存在",
+		langEn:   "working directory %s does not exist",
+	},
+	"workDirNotDir": {
+		langZhTW: "工作目錄 %s 不是目錄",
+		langEn:   "working directory %s is not a directory",
+	},
+	"printConfigHeader": {
+		langZhTW: "MediaHeist 啟動器配置:",
+		langEn:   "MediaHeist launcher configuration:",
+	},
+	"cfgWorkDir": {
+		langZhTW: "  工作目錄: %s",
+		langEn:   "  Working directory: %s",
+	},
+	"cfgLanguage": {
+		langZhTW: "  語系: %s",
+		langEn:   "  Language: %s",
+	},
+	"cfgExtractForced": {
+		langZhTW: "  解壓縮: 需要（--force-extract 會覆蓋所有檔案）",
+		langEn:   "  Extraction: needed (--force-extract overwrites every file)",
+	},
+	"cfgExtractMissing": {
+		langZhTW: "  解壓縮: 需要（缺少 %d 個檔案）",
+		langEn:   "  Extraction: needed (%d files missing)",
+	},
+	"cfgExtractOutdated": {
+		langZhTW: "  解壓縮: 已完成，但有 %d 個檔案與目前版本不一致，執行時會更新",
+		langEn:   "  Extraction: done, but %d files differ from this version and will be updated",
+	},
+	"cfgExtractUpToDate": {
+		langZhTW: "  解壓縮: 不需要（所有檔案皆為最新版本）",
+		langEn:   "  Extraction: not needed (all files are up to date)",
+	},
+	"cfgConfigFound": {
+		langZhTW: "  已找到配置檔案: %s",
+		langEn:   "  Config files found: %s",
+	},
+	"cfgConfigMissing": {
+		langZhTW: "  缺少配置檔案: %s",
+		langEn:   "  Config files missing: %s",
+	},
+	"cfgNone": {
+		langZhTW: "（無）",
+		langEn:   "(none)",
+	},
+	"cfgLauncherCommand": {
+		langZhTW: "  命令: 啟動器子命令 %s（不執行 make）",
+		langEn:   "  Command: launcher subcommand %s (make is not run)",
+	},
+	"cfgLauncherHelp": {
+		langZhTW: "  命令: 顯示啟動器幫助資訊（不執行 make）",
+		langEn:   "  Command: show the launcher help (make is not run)",
+	},
+	"cfgMakePath": {
+		langZhTW: "  make: %s",
+		langEn:   "  make: %s",
+	},
+	"cfgMakeNotFound": {
+		langZhTW: "找不到（請先安裝 make）",
+		langEn:   "not found (install make first)",
+	},
+	"cfgMakeCommand": {
+		langZhTW: "  make 命令: %s",
+		langEn:   "  make command: %s",
+	},
+	"workDirInfo": {
+		langZhTW: "ℹ️ 工作目錄: %s",
+		langEn:   "ℹ️ Working directory: %s",
+	},
+	"runningCommand": {
+		langZhTW: "ℹ️ 執行: %s",
+		langEn:   "ℹ️ Running: %s",
+	},
+
+	// 解壓縮
+	"readManifestFailed": {
+		langZhTW: "讀取嵌入檔案清單失敗",
+		langEn:   "failed to read embedded file list",
+	},
+	"readEmbeddedFailed": {
+		langZhTW: "讀取嵌入檔案 %s 失敗",
+		langEn:   "failed to read embedded file %s",
+	},
+	"hashFailed": {
+		langZhTW: "計算檔案 %s 雜湊失敗",
+		langEn:   "failed to hash file %s",
+	},
+	"mkdirFailed": {
+		langZhTW: "建立目錄 %s 失敗",
+		langEn:   "failed to create directory %s",
+	},
+	"writeFailed": {
+		langZhTW: "寫入檔案 %s 失敗",
+		langEn:   "failed to write file %s",
+	},
+	"chmodFailed": {
+		langZhTW: "設定執行權限失敗 %s",
+		langEn:   "failed to set executable permission on %s",
+	},
+	"extractFailed": {
+		langZhTW: "解壓縮檔案失敗",
+		langEn:   "failed to extract files",
+	},
+	"checkExistingFailed": {
+		langZhTW: "檢查已存在檔案失敗",
+		langEn:   "failed to check existing files",
+	},
+	"extracting": {
+		langZhTW: "ℹ️ 正在解壓縮 MediaHeist 檔案到工作目錄...",
+		langEn:   "ℹ️ Extracting MediaHeist files into the working directory...",
+	},
+	"extractDone": {
+		langZhTW: "✓ 檔案解壓縮完成",
+		langEn:   "✓ Extraction complete",
+	},
+	"extractedFile": {
+		langZhTW: "  已解壓縮: %s",
+		langEn:   "  Extracted: %s",
+	},
+	"partialExtraction": {
+		langZhTW: "⚠️  偵測到不完整的解壓縮，補齊缺少的檔案: %s",
+		langEn:   "⚠️  Incomplete extraction detected, restoring missing files: %s",
+	},
+	"upToDate": {
+		langZhTW: "✓ 檢測到已存在的 MediaHeist 檔案且與目前版本一致，跳過解壓縮",
+		langEn:   "✓ Existing MediaHeist files match this version, skipping extraction",
+	},
+	"outdatedFiles": {
+		langZhTW: "⚠️  以下檔案與目前版本不一致，將重新解壓縮: %s",
+		langEn:   "⚠️  These files differ from this version and will be re-extracted: %s",
+	},
+	"updateDone": {
+		langZhTW: "✓ 檔案更新完成",
+		langEn:   "✓ Files updated",
+	},
+	"ignoredFile": {
+		langZhTW: "  略過（符合 .mediaheistignore）: %s",
+		langEn:   "  Skipped (matches .mediaheistignore): %s",
+	},
+	"readIgnoreFailed": {
+		langZhTW: "讀取 %s 失敗",
+		langEn:   "failed to read %s",
+	},
+	"badIgnorePattern": {
+		langZhTW: "%s 第 %d 行的樣式無效: %q",
+		langEn:   "%s line %d has an invalid pattern: %q",
+	},
+	"unknownExecutableAsset": {
+		langZhTW: "%s 第 %d 行列出的檔案不在嵌入檔案中: %q",
+		langEn:   "%s line %d lists a file that is not embedded: %q",
+	},
+	"unlistedScriptAsset": {
+		langZhTW: "腳本 %s 未列在 %s 中，解壓縮後將無法執行",
+		langEn:   "script %s is not listed in %s and would be extracted without the executable bit",
+	},
+	"duplicateExecutableAsset": {
+		langZhTW: "%s 第 %d 行重複列出: %q",
+		langEn:   "%s line %d is a duplicate: %q",
+	},
+
+	// verify 子命令
+	"verifyHeader": {
+		langZhTW: "ℹ️ 檢查嵌入檔案與工作目錄 %s 的差異（不會寫入任何檔案）",
+		langEn:   "ℹ️ Comparing embedded files with working directory %s (nothing will be written)",
+	},
+	"statusSame": {
+		langZhTW: "相同",
+		langEn:   "same",
+	},
+	"statusNew": {
+		langZhTW: "新增",
+		langEn:   "new",
+	},
+	"statusOverwrite": {
+		langZhTW: "覆蓋",
+		langEn:   "overwrite",
+	},
+	"statusIgnored": {
+		langZhTW: "略過",
+		langEn:   "skip",
+	},
+	"verifySummary": {
+		langZhTW: "✓ 共 %d 個檔案: 新增 %d、覆蓋 %d、相同 %d、略過 %d",
+		langEn:   "✓ %d files: %d new, %d overwritten, %d unchanged, %d skipped",
+	},
+	"verifyOverwriteWarn": {
+		langZhTW: "⚠️  標示為「覆蓋」的檔案與目前版本不一致，執行時將被取代",
+		langEn:   "⚠️  Files marked \"overwrite\" differ from this version and will be replaced on the next run",
+	},
+
+	// targets 子命令
+	"parseMakefileFailed": {
+		langZhTW: "解析 Makefile 失敗",
+		langEn:   "failed to parse Makefile",
+	},
+	"availableTargets": {
+		langZhTW: "可用目標:",
+		langEn:   "Available targets:",
+	},
+
+	// make 檢查
+	"makeNotFound": {
+		langZhTW: "錯誤：找不到 make 指令，MediaHeist 需要 GNU make 才能執行",
+		langEn:   "Error: make was not found; MediaHeist requires GNU make",
+	},
+	"gmakeFound": {
+		langZhTW: "   偵測到 gmake: %s",
+		langEn:   "   Found gmake: %s",
+	},
+	"gmakeLinkHint": {
+		langZhTW: "   請建立名為 make 的連結，例如:",
+		langEn:   "   Create a link named make, for example:",
+	},
+	"installMake": {
+		langZhTW: "   請先安裝 make:",
+		langEn:   "   Please install make first:",
+	},
+	"installMakeBrew": {
+		langZhTW: "     或: brew install make",
+		langEn:   "     or: brew install make",
+	},
+	"installMakeWSL": {
+		langZhTW: "     建議使用 WSL 執行 MediaHeist",
+		langEn:   "     Running MediaHeist under WSL is recommended",
+	},
+	"installMakeMSYS2": {
+		langZhTW: "     或透過 MSYS2 安裝: pacman -S make",
+		langEn:   "     or install it via MSYS2: pacman -S make",
+	},
+	"installMakeOther": {
+		langZhTW: "     請使用系統套件管理器安裝 GNU make",
+		langEn:   "     Install GNU make with your system package manager",
+	},
+
+	// 配置檔案
+	"envDescription": {
+		langZhTW: "環境變數配置（必需）",
+		langEn:   "environment configuration (required)",
+	},
+	"promptDescription": {
+		langZhTW: "摘要提示詞（必需）",
+		langEn:   "summary prompt (required)",
+	},
+	"configFound": {
+		langZhTW: "✓ 找到配置檔案: %s",
+		langEn:   "✓ Found config files: %s",
+	},
+	"configMissing": {
+		langZhTW: "⚠️  缺少配置檔案: %s",
+		langEn:   "⚠️  Missing config files: %s",
+	},
+	"envMissingWarn": {
+		langZhTW: "⚠️  警告: .env 檔案不存在，可能會導致執行失敗",
+		langEn:   "⚠️  Warning: .env does not exist, the run will likely fail",
+	},
+	"envMissingHint": {
+		langZhTW: "   請在工作目錄建立 .env 檔案並設定必要的環境變數",
+		langEn:   "   Create a .env file in the working directory with the required variables",
+	},
+
+	// .env 檢查
+	"envNoEquals": {
+		langZhTW: "缺少 '='，格式應為 KEY=VALUE",
+		langEn:   "missing '=', expected KEY=VALUE",
+	},
+	"envInvalidKey": {
+		langZhTW: "無效的變數名稱 %q",
+		langEn:   "invalid variable name %q",
+	},
+	"envSpacing": {
+		langZhTW: "%s 的等號兩側不應有空格，請改為 %s=VALUE",
+		langEn:   "%s must not have spaces around '=', use %s=VALUE",
+	},
+	"envDuplicate": {
+		langZhTW: "%s 已在第 %d 行定義，將使用此行的值",
+		langEn:   "%s is already defined on line %d, this line's value wins",
+	},
+	"envEmptyRequired": {
+		langZhTW: "必需變數 %s 的值為空",
+		langEn:   "required variable %s is empty",
+	},
+	"envMissingRequired": {
+		langZhTW: "缺少必需變數 %s",
+		langEn:   "missing required variable %s",
+	},
+	"envReadFailed": {
+		langZhTW: "⚠️  無法讀取 .env: %v",
+		langEn:   "⚠️  Cannot read .env: %v",
+	},
+	"envIssueAtLine": {
+		langZhTW: "⚠️  .env 第 %d 行: %s",
+		langEn:   "⚠️  .env line %d: %s",
+	},
+	"envIssue": {
+		langZhTW: "⚠️  .env: %s",
+		langEn:   "⚠️  .env: %s",
+	},
+	"envOK": {
+		langZhTW: "✓ .env 格式正確，必需變數皆已設定",
+		langEn:   "✓ .env is well-formed and all required variables are set",
+	},
+
+	// prompt.txt 檢查
+	"promptMissing": {
+		langZhTW: "⚠️  prompt.txt: 檔案不存在，摘要步驟（pre_srt_summary）將會失敗",
+		langEn:   "⚠️  prompt.txt: file does not exist, the summary step (pre_srt_summary) will fail",
+	},
+	"promptMissingHint": {
+		langZhTW: "   請在工作目錄建立 prompt.txt 作為摘要的系統提示詞",
+		langEn:   "   Create a prompt.txt in the working directory with the summary system prompt",
+	},
+	"promptReadFailed": {
+		langZhTW: "⚠️  無法讀取 prompt.txt: %v",
+		langEn:   "⚠️  Cannot read prompt.txt: %v",
+	},
+	"promptEmpty": {
+		langZhTW: "⚠️  prompt.txt 內容為空，摘要步驟將沒有可用的提示詞",
+		langEn:   "⚠️  prompt.txt is empty, the summary step will run without a prompt",
+	},
+	"promptInvalidUTF8": {
+		langZhTW: "⚠️  prompt.txt 第 %d 行不是有效的 UTF-8 文字，提示詞可能會變成亂碼",
+		langEn:   "⚠️  prompt.txt line %d is not valid UTF-8, the prompt may be garbled",
+	},
+	"promptInfo": {
+		langZhTW: "✓ prompt.txt: %d 位元組、%d 行",
+		langEn:   "✓ prompt.txt: %d bytes, %d lines",
+	},
+	"doctorFailed": {
+		langZhTW: "配置檢查未通過，請依上方提示修正",
+		langEn:   "configuration check failed, fix the issues listed above",
+	},
+	"doctorHeader": {
+		langZhTW: "ℹ️ 檢查工作目錄: %s",
+		langEn:   "ℹ️ Checking working directory: %s",
+	},
+
+	// 說明
+	"help": {
+		langZhTW: helpZhTW,
+		langEn:   helpEn,
+	},
+}
+
+// msg 依目前語系取得訊息並套用參數，缺少翻譯時使用預設語系
+func msg(key string, a ...any) string {
+	translations, ok := messages[key]
+	if !ok {
+		return key
+	}
+
+	format, ok := translations[currentLang]
+	if !ok {
+		format = translations[defaultLang]
+	}
+
+	if len(a) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, a...)
+}
+
+// detectLanguage 依 --lang 參數或 LC_ALL、LC_MESSAGES、LANG 環境變數決定語系
+func detectLanguage(args []string) string {
+	for i, arg := range args {
+		if arg == "--lang" && i+1 < len(args) {
+			return normalizeLanguage(args[i+1])
+		}
+		if value, ok := strings.CutPrefix(arg, "--lang="); ok {
+			return normalizeLanguage(value)
+		}
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(name); value != "" {
+			return normalizeLanguage(value)
+		}
+	}
+	return defaultLang
+}
+
+// normalizeLanguage 將 en_US.UTF-8、zh_TW 等語系字串轉換為支援的語系
+func normalizeLanguage(value string) string {
+	// 去除編碼與修飾詞，例如 en_US.UTF-8、zh_TW@euro
+	value, _, _ = strings.Cut(value, ".")
+	value, _, _ = strings.Cut(value, "@")
+	value = strings.ToLower(strings.ReplaceAll(value, "_", "-"))
+
+	switch {
+	case value == "en" || strings.HasPrefix(value, "en-"):
+		return langEn
+	default:
+		return defaultLang
+	}
+}
+
+const helpZhTW = `MediaHeist - 媒體處理工具包
+
+使用方式:
+  mediaheist [啟動器參數...] <target> [參數...]
+
+啟動器參數:
+  --force-extract                   無條件重新解壓縮並覆蓋所有檔案
+  -C, --workdir <dir>               指定工作目錄（預設為當前目錄）
+  --quiet                           隱藏啟動器的一般訊息（不影響 make 輸出）
+  --verbose                         顯示啟動器的詳細訊息（解壓縮檔案、工作目錄等）
+  --lang <zh-TW|en>                 指定訊息語系（預設依 LANG 環境變數）
+  --print-config                    列出工作目錄、解壓縮狀態、配置檔案與將執行的 make 命令後結束
+
+常用目標:
+  download URL="<url>"              下載並處理單一媒體
+  download LIST="<file>"            批次處理媒體列表
+  all LIST="<file>" MAX_JOBS=<n>    平行處理所有步驟
+  transcribe                        僅執行轉錄步驟
+  frames                           僅執行影格擷取
+  summary                          僅執行摘要生成
+  clean                            清理暫存檔案
+  help                             顯示 Makefile 說明
+  targets                          列出 Makefile 中所有可用目標
+  doctor                           檢查 .env 等配置檔案（不執行 make）
+  verify                           列出將解壓縮、覆蓋及設定執行權限的檔案（不寫入）
+
+支援的輸入格式:
+  - YouTube URLs: https://www.youtube.com/watch?v=VIDEO_ID
+  - YouTube 短網址: https://youtu.be/VIDEO_ID
+  - YouTube 影片 ID: VIDEO_ID (11 字元)
+  - 本地檔案路徑: /absolute/path/to/video.mp4
+
+配置檔案設定:
+  請在工作目錄（預設為執行 mediaheist 的目錄）下放置以下檔案：
+
+  .env - 環境變數配置（必需）:
+    GEMINI_API_KEY=your_gemini_api_key
+    GEMINI_MODEL_ID=gemini-1.5-flash
+    WHISPER_BIN=/usr/local/bin/whisper
+    WHISPER_MODEL=base
+
+  prompt.txt - 摘要提示詞（必需）:
+    AI 摘要生成使用的系統提示詞，缺少時摘要步驟會失敗
+
+執行方式:
+  - 程式會自動將 Makefile 和 scripts 解壓縮到工作目錄（預設為當前目錄，可用 -C 指定）
+  - 所有產生的檔案（下載、轉錄、摘要等）都會在工作目錄
+  - 配置檔案直接從工作目錄讀取，無需複製
+  - 可在工作目錄建立 .mediaheistignore，每行一個樣式（如 Makefile、scripts/*.sh），符合的檔案不會被解壓縮或覆蓋
+
+除錯資訊:
+  - 如果出現 "Missing required variables" 錯誤，請檢查:
+    1. .env 檔案是否存在於工作目錄
+    2. .env 檔案格式是否正確（KEY=VALUE，無空格）
+    3. 所有必需變數是否都已設定
+  - 執行時會顯示找到的配置檔案清單，以及 .env 中有問題的行號
+  - 可執行 mediaheist doctor 單獨檢查配置（包含 prompt.txt 是否為空或非 UTF-8）
+  - 首次執行會解壓縮檔案，之後會自動跳過
+  - 已存在的檔案若與目前版本內容不一致，會自動重新解壓縮
+
+範例:
+  # 在任意目錄下建立 .env 檔案
+  echo "GEMINI_API_KEY=your_key" > .env
+  echo "GEMINI_MODEL_ID=gemini-1.5-flash" >> .env
+  echo "WHISPER_BIN=/usr/local/bin/whisper" >> .env
+  echo "WHISPER_MODEL=base" >> .env
+
+  # 執行 MediaHeist
+  mediaheist download URL="https://youtu.be/dQw4w9WgXcQ"
+  mediaheist download URL="dQw4w9WgXcQ"
+  mediaheist download LIST="urls.txt"
+  mediaheist all LIST="batch.txt" MAX_JOBS=4
+`
+
+const helpEn = `MediaHeist - media processing toolkit
+
+Usage:
+  mediaheist [launcher flags...] <target> [args...]
+
+Launcher flags:
+  --force-extract                   Re-extract and overwrite all files unconditionally
+  -C, --workdir <dir>               Use <dir> as the working directory (default: current directory)
+  --quiet                           Hide launcher status messages (make output is unaffected)
+  --verbose                         Show launcher details (extracted files, working directory, ...)
+  --lang <zh-TW|en>                 Message language (default: from the LANG environment variable)
+  --print-config                    Show the working directory, extraction state, config files and make command, then exit
+
+Common targets:
+  download URL="<url>"              Download and process a single media item
+  download LIST="<file>"            Process a list of media items
+  all LIST="<file>" MAX_JOBS=<n>    Run every stage in parallel
+  transcribe                        Run the transcription stage only
+  frames                           Run frame extraction only
+  summary                          Run summary generation only
+  clean                            Remove temporary files
+  help                             Show the Makefile help
+  targets                          List every available Makefile target
+  doctor                           Check .env and other config files (does not run make)
+  verify                           List files to be extracted, overwritten or made executable (writes nothing)
+
+Supported inputs:
+  - YouTube URLs: https://www.youtube.com/watch?v=VIDEO_ID
+  - YouTube short URLs: https://youtu.be/VIDEO_ID
+  - YouTube video IDs: VIDEO_ID (11 characters)
+  - Local file paths: /absolute/path/to/video.mp4
+
+Configuration:
+  Place these files in the working directory (default: where mediaheist is run):
+
+  .env - environment configuration (required):
+    GEMINI_API_KEY=your_gemini_api_key
+    GEMINI_MODEL_ID=gemini-1.5-flash
+    WHISPER_BIN=/usr/local/bin/whisper
+    WHISPER_MODEL=base
+
+  prompt.txt - summary prompt (required):
+    System prompt used for AI summary generation; the summary step fails without it
+
+How it runs:
+  - The Makefile and scripts are extracted into the working directory (current directory unless -C is given)
+  - All generated files (downloads, transcripts, summaries, ...) are written to the working directory
+  - Config files are read straight from the working directory, no copying needed
+  - A .mediaheistignore file in the working directory lists patterns (one per line, e.g. Makefile, scripts/*.sh) of files that are never extracted or overwritten
+
+Troubleshooting:
+  - If you see a "Missing required variables" error, check that:
+    1. .env exists in the working directory
+    2. .env uses KEY=VALUE lines with no spaces
+    3. every required variable is set
+  - Each run lists the config files found and any .env lines with problems
+  - Run mediaheist doctor to check the configuration on its own (including an empty or non-UTF-8 prompt.txt)
+  - Files are extracted on the first run and skipped afterwards
+  - Existing files that differ from this version are re-extracted automatically
+
+Examples:
+  # Create a .env file in any directory
+  echo "GEMINI_API_KEY=your_key" > .env
+  echo "GEMINI_MODEL_ID=gemini-1.5-flash" >> .env
+  echo "WHISPER_BIN=/usr/local/bin/whisper" >> .env
+  echo "WHISPER_MODEL=base" >> .env
+
+  # Run MediaHeist
+  mediaheist download URL="https://youtu.be/dQw4w9WgXcQ"
+  mediaheist download URL="dQw4w9WgXcQ"
+  mediaheist download LIST="urls.txt"
+  mediaheist all LIST="batch.txt" MAX_JOBS=4
+`
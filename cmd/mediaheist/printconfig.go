@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// shellSafeArg 不需要加引號即可在 shell 中使用的參數
+var shellSafeArg = regexp.MustCompile(`^[A-Za-z0-9_@%+=:,./-]+$`)
+
+// printConfig 列出解析後的工作目錄、解壓縮狀態、配置檔案與將執行的 make 命令（不寫入任何檔案）
+func printConfig(dir string, opts launcherOptions, makeArgs []string) error {
+	fmt.Println(msg("printConfigHeader"))
+	fmt.Println(msg("cfgWorkDir", dir))
+	fmt.Println(msg("cfgLanguage", currentLang))
+
+	// 解壓縮狀態（與 prepareAssets 相同的判斷，但只回報）
+	missing, err := findMissingAssets(dir)
+	if err != nil {
+		return err
+	}
+	switch {
+	case opts.forceExtract:
+		fmt.Println(msg("cfgExtractForced"))
+	case len(missing) > 0:
+		fmt.Println(msg("cfgExtractMissing", len(missing)))
+	default:
+		manifest, err := loadAssetManifest(dir)
+		if err != nil {
+			return err
+		}
+		outdated, err := findOutdatedFiles(dir, manifest)
+		if err != nil {
+			return fmt.Errorf("%s: %w", msg("checkExistingFailed"), err)
+		}
+		if len(outdated) > 0 {
+			fmt.Println(msg("cfgExtractOutdated", len(outdated)))
+		} else {
+			fmt.Println(msg("cfgExtractUpToDate"))
+		}
+	}
+
+	// 配置檔案
+	found, missingConfig := scanConfigFiles(dir)
+	fmt.Println(msg("cfgConfigFound", joinOrNone(found)))
+	fmt.Println(msg("cfgConfigMissing", joinOrNone(missingConfig)))
+
+	// 將執行的命令
+	if len(makeArgs) > 0 && isHelpArg(makeArgs[0]) {
+		fmt.Println(msg("cfgLauncherHelp"))
+		return nil
+	}
+	if len(makeArgs) > 0 && launcherCommands[makeArgs[0]] != nil {
+		fmt.Println(msg("cfgLauncherCommand", makeArgs[0]))
+		return nil
+	}
+	if makePath, err := exec.LookPath("make"); err == nil {
+		fmt.Println(msg("cfgMakePath", makePath))
+	} else {
+		fmt.Println(msg("cfgMakePath", msg("cfgMakeNotFound")))
+	}
+	fmt.Println(msg("cfgMakeCommand", shellJoin(buildMakeArgs(makeArgs))))
+	return nil
+}
+
+// shellJoin 將命令參數組成可直接複製到 shell 執行的字串，必要時以單引號包住參數
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if shellSafeArg.MatchString(arg) {
+			quoted[i] = arg
+		} else {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+// joinOrNone 以逗號串接清單，空清單時顯示「無」
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return msg("cfgNone")
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// targetLinePattern 比對 Makefile 中的目標定義（排除 := 之類的變數指定）
+var targetLinePattern = regexp.MustCompile(`^([a-zA-Z0-9_-]+):([^=]|$)`)
+
+// makeTarget 描述 Makefile 中的一個目標及其說明
+type makeTarget struct {
+	name string
+	doc  string
+}
+
+// parseMakeTargets 解析 Makefile 的目標定義與其上方的 ## 說明註解
+func parseMakeTargets(path string) ([]makeTarget, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var targets []makeTarget
+	seen := make(map[string]int)
+	var docLines []string
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		// 收集緊接在目標上方的 ## 說明
+		if strings.HasPrefix(line, "##") {
+			docLines = append(docLines, strings.TrimSpace(strings.TrimPrefix(line, "##")))
+			continue
+		}
+
+		match := targetLinePattern.FindStringSubmatch(line)
+		if match == nil {
+			// .PHONY 宣告不會打斷說明註解
+			if !strings.HasPrefix(line, ".PHONY") {
+				docLines = nil
+			}
+			continue
+		}
+
+		name := match[1]
+		doc := strings.Join(docLines, " ")
+		docLines = nil
+
+		// 同一目標可能出現多次（例如額外的相依宣告），只保留第一次並補上說明
+		if idx, ok := seen[name]; ok {
+			if targets[idx].doc == "" {
+				targets[idx].doc = doc
+			}
+			continue
+		}
+		seen[name] = len(targets)
+		targets = append(targets, makeTarget{name: name, doc: doc})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// printTargets 列出 Makefile 中可用的目標
+func printTargets(makefilePath string) error {
+	targets, err := parseMakeTargets(makefilePath)
+	if err != nil {
+		return fmt.Errorf("%s: %w", msg("parseMakefileFailed"), err)
+	}
+
+	fmt.Println(msg("availableTargets"))
+	for _, t := range targets {
+		if t.doc != "" {
+			fmt.Printf("  %-20s %s\n", t.name, t.doc)
+		} else {
+			fmt.Printf("  %s\n", t.name)
+		}
+	}
+	return nil
+}
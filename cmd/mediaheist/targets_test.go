@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseMakeTargets(t *testing.T) {
+	tests := []struct {
+		name     string
+		makefile string
+		want     []makeTarget
+	}{
+		{
+			name:     "documented target",
+			makefile: "## 下載媒體\n## 第二行\ndownload: deps\n\t@echo ok\n",
+			want:     []makeTarget{{"download", "下載媒體 第二行"}},
+		},
+		{
+			name:     "undocumented target",
+			makefile: "clean:\n\trm -rf tmp\n",
+			want:     []makeTarget{{"clean", ""}},
+		},
+		{
+			name:     "phony keeps doc",
+			makefile: "## 清理\n.PHONY: clean\nclean:\n",
+			want:     []makeTarget{{"clean", "清理"}},
+		},
+		{
+			name:     "other lines reset doc",
+			makefile: "## 孤立的說明\n\nclean:\n",
+			want:     []makeTarget{{"clean", ""}},
+		},
+		{
+			name:     "variable assignments are not targets",
+			makefile: "OUT:=out\nMAX_JOBS ?= 4\nSHELL := /bin/bash\nall:\n",
+			want:     []makeTarget{{"all", ""}},
+		},
+		{
+			name:     "pattern rules are not targets",
+			makefile: "$(OUT)/%.wav: %.mp4\nall:\n",
+			want:     []makeTarget{{"all", ""}},
+		},
+		{
+			name:     "duplicate target keeps first and fills doc",
+			makefile: "all: a\n## 全部\nall: b\n## 另一個說明\nall: c\n",
+			want:     []makeTarget{{"all", "全部"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseMakeTargets(writeTempFile(t, "Makefile", tt.makefile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("targets = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}